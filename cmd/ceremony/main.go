@@ -0,0 +1,121 @@
+// Command ceremony drives one contributor's step in a multi-party binary
+// fusion tap ceremony: it reads the current state, mixes in a secret,
+// writes the new state, appends the signed contribution to the chain file
+// and, when -receipt is set, writes the receipt, mirroring the output style
+// of the original binary_fusion_tap.go CLI.
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/beanapologist/seed/pkg/ceremony"
+	"github.com/beanapologist/seed/pkg/fusion"
+)
+
+func main() {
+	var (
+		statePath   = flag.String("state", "prev_state.json", "path to the current ceremony state (prev_state.json)")
+		secretFile  = flag.String("secret-file", "", "path to a file holding the contributor secret; if unset, the secret is read from stdin")
+		chainPath   = flag.String("chain", "ceremony_chain.jsonl", "path to append this contributor's Contribution record to")
+		k           = flag.Int("k", 11, "tap parameter used only when -state does not yet exist")
+		receiptPath = flag.String("receipt", "", "optional path to write this contributor's Receipt as JSON")
+		verifyChain = flag.Bool("verify", false, "verify -chain instead of contributing, and exit")
+	)
+	flag.Parse()
+
+	if *verifyChain {
+		chain, err := ceremony.LoadChain(*chainPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ceremony:", err)
+			os.Exit(1)
+		}
+		if err := ceremony.Verify(chain); err != nil {
+			fmt.Fprintln(os.Stderr, "ceremony: chain invalid:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("ceremony: chain of %d contribution(s) verified ok\n", len(chain))
+		return
+	}
+
+	secret, err := readSecret(*secretFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ceremony:", err)
+		os.Exit(1)
+	}
+
+	prev, err := ceremony.LoadState(*statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		prev = fusion.BinaryFusionTap(*k)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "ceremony:", err)
+		os.Exit(1)
+	}
+
+	_, signer, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ceremony: generate signing key:", err)
+		os.Exit(1)
+	}
+
+	next, contribution, receipt, err := ceremony.Contribute(prev, secret, signer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ceremony:", err)
+		os.Exit(1)
+	}
+
+	if err := ceremony.SaveState(*statePath, next); err != nil {
+		fmt.Fprintln(os.Stderr, "ceremony:", err)
+		os.Exit(1)
+	}
+	if err := ceremony.AppendContribution(*chainPath, contribution); err != nil {
+		fmt.Fprintln(os.Stderr, "ceremony:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("K Parameter: %d\n", next.K)
+	fmt.Printf("Seed Value: %s\n", next.SeedValue.String())
+	fmt.Printf("Tap State: 0b%s\n", next.TapState.Text(2))
+	fmt.Printf("ZPE Overflow: 0b%s\n", next.ZPEOverflowBinary)
+	fmt.Printf("Transcript Digest: %s\n", base64.StdEncoding.EncodeToString(contribution.Digest))
+
+	if *receiptPath != "" {
+		data, err := json.MarshalIndent(receipt, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ceremony: encode receipt:", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(*receiptPath, data, 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, "ceremony: write receipt:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// readSecret reads the contributor secret from path, or from stdin when
+// path is empty, so it never appears in argv (visible via ps/procfs) or
+// shell history.
+func readSecret(path string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path != "" {
+		data, err = os.ReadFile(path)
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read secret: %w", err)
+	}
+	return bytes.TrimRight(data, "\n"), nil
+}
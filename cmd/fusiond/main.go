@@ -0,0 +1,121 @@
+// Command fusiond serves the binary fusion tap as a gRPC seed oracle, so
+// downstream key-derivation daemons can call Generate/Verify instead of
+// linking pkg/fusion directly.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+	"github.com/beanapologist/seed/pkg/fusionpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxK bounds the tap parameter a single request may ask for. Past
+	// this, the accumulator itself becomes a meaningful amount of memory
+	// and CPU, which an untrusted client could otherwise spend freely.
+	maxK = 20_000
+
+	// memCeilingBytes is the soft cap passed to BinaryFusionTapCtx for
+	// each request, independent of maxK.
+	memCeilingBytes = 64 << 20
+)
+
+type server struct {
+	fusionpb.UnimplementedFusionTapServer
+}
+
+// validateK rejects k values that are non-positive or past maxK, before
+// any work is done on them.
+func validateK(k int64) error {
+	if k <= 0 {
+		return status.Error(codes.InvalidArgument, "k must be positive")
+	}
+	if k > maxK {
+		return status.Errorf(codes.InvalidArgument, "k must be <= %d", maxK)
+	}
+	return nil
+}
+
+func toProto(res *fusion.BinaryFusionResult) *fusionpb.BinaryFusionResult {
+	return &fusionpb.BinaryFusionResult{
+		K:                 int64(res.K),
+		SeedValue:         res.SeedValue.Bytes(),
+		BinarySeed:        res.BinarySeed,
+		TapState:          res.TapState.Bytes(),
+		ZPEOverflow:       res.ZPEOverflow.Bytes(),
+		ZPEOverflowBinary: res.ZPEOverflowBinary,
+	}
+}
+
+func (s *server) Generate(ctx context.Context, req *fusionpb.GenerateRequest) (*fusionpb.BinaryFusionResult, error) {
+	if err := validateK(req.K); err != nil {
+		return nil, err
+	}
+	res, err := fusion.BinaryFusionTapCtx(ctx, int(req.K), fusion.Options{MemoryCeiling: memCeilingBytes})
+	if err != nil {
+		var memErr *fusion.ErrMemoryLimit
+		if errors.As(err, &memErr) {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toProto(res), nil
+}
+
+func (s *server) GenerateStream(req *fusionpb.GenerateRequest, stream fusionpb.FusionTap_GenerateStreamServer) error {
+	if err := validateK(req.KEnd - 1); err != nil {
+		return err
+	}
+	if req.KStart < 1 || req.KEnd <= req.KStart {
+		return status.Error(codes.InvalidArgument, "k_start must be >= 1 and less than k_end")
+	}
+
+	return fusion.BinaryFusionTapStream(stream.Context(), int(req.KEnd-1), func(res *fusion.BinaryFusionResult) error {
+		if int64(res.K) < req.KStart {
+			return nil
+		}
+		return stream.Send(toProto(res))
+	})
+}
+
+func (s *server) Verify(ctx context.Context, in *fusionpb.BinaryFusionResult) (*fusionpb.VerifyResponse, error) {
+	if err := validateK(in.K); err != nil {
+		return nil, err
+	}
+	res, err := fusion.BinaryFusionTapCtx(ctx, int(in.K), fusion.Options{MemoryCeiling: memCeilingBytes})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	want := toProto(res)
+	if string(want.TapState) != string(in.TapState) || want.ZPEOverflowBinary != in.ZPEOverflowBinary {
+		return &fusionpb.VerifyResponse{Valid: false, Message: "recomputed tap state does not match"}, nil
+	}
+	return &fusionpb.VerifyResponse{Valid: true, Message: "ok"}, nil
+}
+
+func main() {
+	addr := flag.String("addr", ":8911", "address to listen on")
+	flag.Parse()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("fusiond: listen: %v", err)
+	}
+
+	s := grpc.NewServer(fusionpb.ServerCodec())
+	fusionpb.RegisterFusionTapServer(s, &server{})
+
+	fmt.Printf("fusiond: serving FusionTap on %s\n", *addr)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("fusiond: serve: %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Package ceremony implements a powers-of-tau-style sequential contribution
+// protocol over a fusion.BinaryFusionResult: each participant mixes a
+// secret into the current tap state and hands the result, along with a
+// transcript digest and a signed receipt, to the next participant. As long
+// as one contributor's secret was honest, the final TapState is
+// unpredictable to everyone else in the chain.
+package ceremony
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Contribution is one step of the ceremony transcript: the new tap state
+// plus enough of the chain history to let Verify replay it without ever
+// seeing the contributor's secret.
+type Contribution struct {
+	State      *fusion.BinaryFusionResult `json:"state"`
+	PrevDigest []byte                     `json:"prev_digest"`
+	Digest     []byte                     `json:"digest"`
+	Signature  []byte                     `json:"signature"`
+	PublicKey  ed25519.PublicKey          `json:"public_key"`
+}
+
+// Receipt is the public proof that a contributor participated. It carries
+// no information about the secret that was mixed in.
+type Receipt struct {
+	PublicKey ed25519.PublicKey `json:"public_key"`
+	Digest    []byte            `json:"digest"`
+	Signature []byte            `json:"signature"`
+}
+
+// genesisDigest hashes res's TapState. Contribute uses it as the PrevDigest
+// of every step, not only the first, so each Digest covers the state a step
+// started from and the state it produced; earlier Digest values are not
+// folded into later ones.
+func genesisDigest(res *fusion.BinaryFusionResult) []byte {
+	sum := blake2b.Sum256(res.TapState.Bytes())
+	return sum[:]
+}
+
+// Contribute mixes secret into prev's tap state, producing the next
+// BinaryFusionResult in the ceremony along with the Contribution record
+// and a Receipt the contributor can publish.
+func Contribute(prev *fusion.BinaryFusionResult, secret []byte, signer ed25519.PrivateKey) (*fusion.BinaryFusionResult, *Contribution, *Receipt, error) {
+	if prev == nil || prev.TapState == nil || prev.SeedValue == nil {
+		return nil, nil, nil, errors.New("ceremony: prev state is incomplete")
+	}
+	if len(secret) == 0 {
+		return nil, nil, nil, errors.New("ceremony: secret must not be empty")
+	}
+
+	h := blake2b.Sum256(secret)
+	hInt := new(big.Int).SetBytes(h[:])
+
+	// TapState' = (TapState XOR H(s_i)) << 3 + k
+	mixed := new(big.Int).Xor(prev.TapState, hInt)
+	mixed.Lsh(mixed, 3)
+	mixed.Add(mixed, big.NewInt(int64(prev.K)))
+
+	var overflow *big.Int
+	if prev.K < 10 {
+		overflow = big.NewInt(0)
+	} else {
+		mult := new(big.Int).Mul(prev.SeedValue, big.NewInt(8))
+		overflow = new(big.Int).Xor(mixed, mult)
+	}
+
+	next := &fusion.BinaryFusionResult{
+		K:                 prev.K,
+		SeedValue:         prev.SeedValue,
+		BinarySeed:        prev.BinarySeed,
+		TapState:          mixed,
+		ZPEOverflow:       overflow,
+		ZPEOverflowBinary: overflow.Text(2),
+	}
+
+	prevDigest := genesisDigest(prev)
+	digest := chainDigest(prevDigest, next.TapState)
+
+	if signer == nil {
+		return nil, nil, nil, errors.New("ceremony: signer must not be nil")
+	}
+	sig := ed25519.Sign(signer, digest)
+
+	contribution := &Contribution{
+		State:      next,
+		PrevDigest: prevDigest,
+		Digest:     digest,
+		Signature:  sig,
+		PublicKey:  signer.Public().(ed25519.PublicKey),
+	}
+	receipt := &Receipt{
+		PublicKey: contribution.PublicKey,
+		Digest:    digest,
+		Signature: sig,
+	}
+
+	return next, contribution, receipt, nil
+}
+
+// chainDigest computes H(prevDigest || new_TapState_bytes).
+func chainDigest(prevDigest []byte, tapState *big.Int) []byte {
+	h, _ := blake2b.New256(nil)
+	h.Write(prevDigest)
+	h.Write(tapState.Bytes())
+	return h.Sum(nil)
+}
+
+// Verify re-runs the transcript for a chain of contributions, checking
+// that each step's digest chains from the previous one and that every
+// signature is valid. The first entry's PrevDigest is trusted as the
+// ceremony root and is not re-derived here; every later PrevDigest is
+// recomputed from the prior contribution's state (as Contribute itself
+// does), not copied from the prior Digest field.
+func Verify(chain []*Contribution) error {
+	if len(chain) == 0 {
+		return errors.New("ceremony: empty chain")
+	}
+
+	prevDigest := chain[0].PrevDigest
+	for i, c := range chain {
+		if c.State == nil || c.State.TapState == nil {
+			return fmt.Errorf("ceremony: contribution %d has no state", i)
+		}
+		wantDigest := chainDigest(prevDigest, c.State.TapState)
+		if string(wantDigest) != string(c.Digest) {
+			return fmt.Errorf("ceremony: contribution %d digest mismatch", i)
+		}
+		if !ed25519.Verify(c.PublicKey, c.Digest, c.Signature) {
+			return fmt.Errorf("ceremony: contribution %d signature invalid", i)
+		}
+		prevDigest = genesisDigest(c.State)
+	}
+	return nil
+}
+
+// LoadState reads a BinaryFusionResult previously written by SaveState.
+func LoadState(path string) (*fusion.BinaryFusionResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ceremony: read state: %w", err)
+	}
+	var res fusion.BinaryFusionResult
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("ceremony: decode state: %w", err)
+	}
+	return &res, nil
+}
+
+// SaveState writes res to path as prev_state.json for the next contributor.
+func SaveState(path string, res *fusion.BinaryFusionResult) error {
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ceremony: encode state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("ceremony: write state: %w", err)
+	}
+	return nil
+}
+
+// AppendContribution appends c as one JSON line to the ceremony's chain
+// file at path, creating it if necessary. The resulting file is exactly
+// what LoadChain and Verify expect.
+func AppendContribution(path string, c *Contribution) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("ceremony: open chain: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("ceremony: encode contribution: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("ceremony: write contribution: %w", err)
+	}
+	return nil
+}
+
+// LoadChain reads the newline-delimited Contribution records written by
+// AppendContribution, in order, for use with Verify.
+func LoadChain(path string) ([]*Contribution, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("ceremony: open chain: %w", err)
+	}
+	defer f.Close()
+
+	var chain []*Contribution
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var c Contribution
+		if err := json.Unmarshal(line, &c); err != nil {
+			return nil, fmt.Errorf("ceremony: decode contribution: %w", err)
+		}
+		chain = append(chain, &c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ceremony: read chain: %w", err)
+	}
+	return chain, nil
+}
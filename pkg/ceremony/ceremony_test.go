@@ -0,0 +1,68 @@
+package ceremony
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+)
+
+func TestContributeThenVerify(t *testing.T) {
+	prev := fusion.BinaryFusionTap(11)
+
+	var chain []*Contribution
+	for _, secret := range [][]byte{[]byte("alice-secret"), []byte("bob-secret")} {
+		_, signer, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("generate key: %v", err)
+		}
+		next, contribution, receipt, err := Contribute(prev, secret, signer)
+		if err != nil {
+			t.Fatalf("Contribute: %v", err)
+		}
+		if !ed25519.Verify(receipt.PublicKey, receipt.Digest, receipt.Signature) {
+			t.Fatal("receipt signature does not verify")
+		}
+		chain = append(chain, contribution)
+		prev = next
+	}
+
+	if err := Verify(chain); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	// Tampering with a later state must break verification.
+	chain[1].State.TapState.Add(chain[1].State.TapState, big.NewInt(1))
+	if err := Verify(chain); err == nil {
+		t.Fatal("expected Verify to reject a tampered chain")
+	}
+}
+
+func TestAppendAndLoadChain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "chain.jsonl")
+
+	prev := fusion.BinaryFusionTap(11)
+	_, signer, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	_, contribution, _, err := Contribute(prev, []byte("secret"), signer)
+	if err != nil {
+		t.Fatalf("Contribute: %v", err)
+	}
+	if err := AppendContribution(path, contribution); err != nil {
+		t.Fatalf("AppendContribution: %v", err)
+	}
+
+	chain, err := LoadChain(path)
+	if err != nil {
+		t.Fatalf("LoadChain: %v", err)
+	}
+	if err := Verify(chain); err != nil {
+		t.Fatalf("Verify loaded chain: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Package fusion implements the binary fusion tap: a deterministic seed
+// generator that concatenates 1..k, folds it through an 8-fold heartbeat
+// shift and exposes the resulting ZPE overflow. It is the library form of
+// the algorithm first published as releases/binary-fusion-tap-v1.0.0.
+package fusion
+
+import "math/big"
+
+// BinaryFusionResult holds the key generation data.
+type BinaryFusionResult struct {
+	K                 int
+	SeedValue         *big.Int
+	BinarySeed        string
+	TapState          *big.Int
+	ZPEOverflow       *big.Int
+	ZPEOverflowBinary string
+}
+
+// BinaryFusionTap generates binary fusion tap with 8-fold heartbeat and ZPE overflow.
+// k: Tap parameter (recommended: 11 for optimal entropy)
+func BinaryFusionTap(k int) *BinaryFusionResult {
+	acc := newAccumulator()
+	for i := 1; i <= k; i++ {
+		acc.add(i)
+	}
+	return finalize(k, acc.seedVal)
+}
+
+// accumulator builds the seed value for 1..k incrementally: seedVal =
+// seedVal*10^digits(i) + i, so the decimal concatenation is never
+// materialized as a string.
+type accumulator struct {
+	seedVal *big.Int
+	pow10   *big.Int
+	digits  int
+}
+
+func newAccumulator() *accumulator {
+	return &accumulator{seedVal: new(big.Int), pow10: big.NewInt(1), digits: 0}
+}
+
+func (a *accumulator) add(i int) {
+	if d := numDigits(i); d != a.digits {
+		a.pow10 = new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d)), nil)
+		a.digits = d
+	}
+	a.seedVal.Mul(a.seedVal, a.pow10)
+	a.seedVal.Add(a.seedVal, big.NewInt(int64(i)))
+}
+
+func numDigits(i int) int {
+	d := 1
+	for i >= 10 {
+		i /= 10
+		d++
+	}
+	return d
+}
+
+// finalize applies the 8-fold heartbeat shift, phase offset and ZPE
+// overflow extraction to a seed value accumulated through k.
+func finalize(k int, seedVal *big.Int) *BinaryFusionResult {
+	// 1. Apply 8-fold Heartbeat (bit-shift left by 3)
+	heartbeatVal := new(big.Int).Lsh(seedVal, 3)
+
+	// 2. Add Phase Offset
+	manifested := new(big.Int).Add(heartbeatVal, big.NewInt(int64(k)))
+
+	// 3. Extract ZPE Overflow
+	var overflow *big.Int
+	if k < 10 {
+		overflow = big.NewInt(0)
+	} else {
+		mult := new(big.Int).Mul(seedVal, big.NewInt(8))
+		overflow = new(big.Int).Xor(manifested, mult)
+	}
+
+	return &BinaryFusionResult{
+		K:                 k,
+		SeedValue:         new(big.Int).Set(seedVal),
+		BinarySeed:        seedVal.Text(2),
+		TapState:          manifested,
+		ZPEOverflow:       overflow,
+		ZPEOverflowBinary: overflow.Text(2),
+	}
+}
@@ -0,0 +1,81 @@
+package fusion
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/debug"
+)
+
+// Options configures BinaryFusionTapCtx.
+type Options struct {
+	// MemoryCeiling bounds the accumulator's size in bytes. If zero, the
+	// current GOMEMLIMIT (via runtime/debug.SetMemoryLimit) is honored when
+	// one is set; otherwise there is no ceiling.
+	MemoryCeiling uint64
+
+	// ProgressEvery, if positive, invokes Progress every N values of k.
+	ProgressEvery int
+	Progress      func(k int, res *BinaryFusionResult)
+}
+
+// ErrMemoryLimit is returned by BinaryFusionTapCtx when the accumulator
+// would grow past the configured memory ceiling. MaxK is the largest k
+// that fit within it.
+type ErrMemoryLimit struct {
+	MaxK int
+}
+
+func (e *ErrMemoryLimit) Error() string {
+	return fmt.Sprintf("fusion: seed would exceed memory ceiling past k=%d", e.MaxK)
+}
+
+func resolveCeiling(configured uint64) uint64 {
+	if configured != 0 {
+		return configured
+	}
+	if lim := debug.SetMemoryLimit(-1); lim > 0 && lim < math.MaxInt64 {
+		return uint64(lim)
+	}
+	return 0
+}
+
+// BinaryFusionTapCtx is BinaryFusionTap with cancellation, a soft memory
+// ceiling and progress reporting, for k large enough that the accumulator
+// itself becomes a meaningful amount of memory.
+func BinaryFusionTapCtx(ctx context.Context, k int, opts Options) (*BinaryFusionResult, error) {
+	ceiling := resolveCeiling(opts.MemoryCeiling)
+	acc := newAccumulator()
+
+	for i := 1; i <= k; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		acc.add(i)
+		if ceiling > 0 && uint64(len(acc.seedVal.Bytes())) > ceiling {
+			return nil, &ErrMemoryLimit{MaxK: i - 1}
+		}
+		if opts.ProgressEvery > 0 && opts.Progress != nil && i%opts.ProgressEvery == 0 {
+			opts.Progress(i, finalize(i, acc.seedVal))
+		}
+	}
+	return finalize(k, acc.seedVal), nil
+}
+
+// BinaryFusionTapStream yields the BinaryFusionResult for every k in
+// 1..kMax in a single pass, reusing one accumulator so the total work is
+// O(k * M(n)) instead of the O(k^2) of calling BinaryFusionTap in a loop.
+// It stops, returning fn's error, as soon as fn returns a non-nil error.
+func BinaryFusionTapStream(ctx context.Context, kMax int, fn func(*BinaryFusionResult) error) error {
+	acc := newAccumulator()
+	for i := 1; i <= kMax; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		acc.add(i)
+		if err := fn(finalize(i, acc.seedVal)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package fusion
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBinaryFusionTapCtxMatchesBinaryFusionTap(t *testing.T) {
+	for _, k := range []int{1, 9, 10, 11, 100, 1000} {
+		want := BinaryFusionTap(k)
+		got, err := BinaryFusionTapCtx(context.Background(), k, Options{})
+		if err != nil {
+			t.Fatalf("k=%d: %v", k, err)
+		}
+		if got.SeedValue.Cmp(want.SeedValue) != 0 || got.TapState.Cmp(want.TapState) != 0 {
+			t.Fatalf("k=%d: BinaryFusionTapCtx disagrees with BinaryFusionTap", k)
+		}
+	}
+}
+
+func TestBinaryFusionTapCtxMemoryLimit(t *testing.T) {
+	_, err := BinaryFusionTapCtx(context.Background(), 1000, Options{MemoryCeiling: 1})
+	var memErr *ErrMemoryLimit
+	if !errors.As(err, &memErr) {
+		t.Fatalf("expected *ErrMemoryLimit, got %v", err)
+	}
+	if memErr.MaxK < 1 {
+		t.Fatalf("expected a positive MaxK, got %d", memErr.MaxK)
+	}
+}
+
+func TestBinaryFusionTapCtxCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := BinaryFusionTapCtx(ctx, 100, Options{}); err == nil {
+		t.Fatal("expected an error from an already-cancelled context")
+	}
+}
+
+func TestBinaryFusionTapStreamMatchesBinaryFusionTap(t *testing.T) {
+	seen := 0
+	err := BinaryFusionTapStream(context.Background(), 20, func(res *BinaryFusionResult) error {
+		seen++
+		want := BinaryFusionTap(res.K)
+		if res.SeedValue.Cmp(want.SeedValue) != 0 || res.TapState.Cmp(want.TapState) != 0 {
+			t.Fatalf("k=%d: BinaryFusionTapStream disagrees with BinaryFusionTap", res.K)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("BinaryFusionTapStream: %v", err)
+	}
+	if seen != 20 {
+		t.Fatalf("expected 20 callbacks, got %d", seen)
+	}
+}
+
+func TestBinaryFusionTapStreamStopsOnCallbackError(t *testing.T) {
+	stopAt := 5
+	errStop := errors.New("stop")
+	seen := 0
+	err := BinaryFusionTapStream(context.Background(), 20, func(res *BinaryFusionResult) error {
+		seen++
+		if res.K == stopAt {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected errStop, got %v", err)
+	}
+	if seen != stopAt {
+		t.Fatalf("expected to stop after %d callbacks, got %d", stopAt, seen)
+	}
+}
@@ -0,0 +1,101 @@
+// Package fusionclient is a thin client for the FusionTap gRPC service,
+// converting between fusionpb wire messages and pkg/fusion's native types.
+package fusionclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+	"github.com/beanapologist/seed/pkg/fusionpb"
+	"google.golang.org/grpc"
+)
+
+// Client talks to a fusiond FusionTap server.
+type Client struct {
+	conn *grpc.ClientConn
+	rpc  fusionpb.FusionTapClient
+}
+
+// Dial connects to a fusiond instance at addr. Calls made through the
+// returned Client use fusionpb's own wire format (via a dedicated
+// content-subtype), leaving grpc's default "proto" codec untouched for any
+// other service sharing this process.
+func Dial(addr string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{
+		grpc.WithDefaultCallOptions(fusionpb.ClientCallOption()),
+	}, opts...)
+	conn, err := grpc.Dial(addr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{conn: conn, rpc: fusionpb.NewFusionTapClient(conn)}, nil
+}
+
+// Close tears down the underlying gRPC connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func fromProto(m *fusionpb.BinaryFusionResult) *fusion.BinaryFusionResult {
+	return &fusion.BinaryFusionResult{
+		K:                 int(m.K),
+		SeedValue:         new(big.Int).SetBytes(m.SeedValue),
+		BinarySeed:        m.BinarySeed,
+		TapState:          new(big.Int).SetBytes(m.TapState),
+		ZPEOverflow:       new(big.Int).SetBytes(m.ZPEOverflow),
+		ZPEOverflowBinary: m.ZPEOverflowBinary,
+	}
+}
+
+func toProto(res *fusion.BinaryFusionResult) *fusionpb.BinaryFusionResult {
+	return &fusionpb.BinaryFusionResult{
+		K:                 int64(res.K),
+		SeedValue:         res.SeedValue.Bytes(),
+		BinarySeed:        res.BinarySeed,
+		TapState:          res.TapState.Bytes(),
+		ZPEOverflow:       res.ZPEOverflow.Bytes(),
+		ZPEOverflowBinary: res.ZPEOverflowBinary,
+	}
+}
+
+// Generate asks the server for the tap at k.
+func (c *Client) Generate(ctx context.Context, k int) (*fusion.BinaryFusionResult, error) {
+	res, err := c.rpc.Generate(ctx, &fusionpb.GenerateRequest{K: int64(k)})
+	if err != nil {
+		return nil, err
+	}
+	return fromProto(res), nil
+}
+
+// GenerateStream asks the server for every tap in [kStart, kEnd), calling fn
+// with each one in order until the stream ends or fn returns an error.
+func (c *Client) GenerateStream(ctx context.Context, kStart, kEnd int, fn func(*fusion.BinaryFusionResult) error) error {
+	stream, err := c.rpc.GenerateStream(ctx, &fusionpb.GenerateRequest{KStart: int64(kStart), KEnd: int64(kEnd)})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := fn(fromProto(res)); err != nil {
+			return err
+		}
+	}
+}
+
+// Verify asks the server to recompute res.K and confirm it matches res.
+func (c *Client) Verify(ctx context.Context, res *fusion.BinaryFusionResult) (bool, string, error) {
+	resp, err := c.rpc.Verify(ctx, toProto(res))
+	if err != nil {
+		return false, "", err
+	}
+	return resp.Valid, resp.Message, nil
+}
@@ -0,0 +1,63 @@
+// codec.go registers the gogoproto-style marshaler codec so grpc uses the
+// hand-written Marshal/Unmarshal methods in fusion.pb.go instead of
+// reflection. It is registered under its own content-subtype rather than
+// grpc's default "proto" codec, so linking this package never changes how
+// any other service in the same process marshals its messages; FusionTap
+// traffic opts in explicitly via ServerCodec/ClientCallOption below.
+package fusionpb
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the gRPC content-subtype this package's traffic is served
+// and dialed under (negotiated as "application/grpc+" + codecName).
+const codecName = "fusionpb-proto"
+
+type gogoMarshaler interface {
+	Marshal() ([]byte, error)
+}
+
+type gogoUnmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+type gogoCodec struct{}
+
+func (gogoCodec) Name() string { return codecName }
+
+func (gogoCodec) Marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(gogoMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("fusionpb: %T does not implement Marshal() ([]byte, error)", v)
+	}
+	return m.Marshal()
+}
+
+func (gogoCodec) Unmarshal(data []byte, v interface{}) error {
+	m, ok := v.(gogoUnmarshaler)
+	if !ok {
+		return fmt.Errorf("fusionpb: %T does not implement Unmarshal([]byte) error", v)
+	}
+	return m.Unmarshal(data)
+}
+
+func init() {
+	encoding.RegisterCodec(gogoCodec{})
+}
+
+// ServerCodec returns the grpc.ServerOption that makes a *grpc.Server speak
+// this package's wire format, without touching any other codec registered
+// in the process.
+func ServerCodec() grpc.ServerOption {
+	return grpc.ForceServerCodec(gogoCodec{})
+}
+
+// ClientCallOption returns the grpc.CallOption that makes a client call use
+// this package's wire format for that call only.
+func ClientCallOption() grpc.CallOption {
+	return grpc.CallContentSubtype(codecName)
+}
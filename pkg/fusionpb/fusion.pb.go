@@ -0,0 +1,171 @@
+// fusion.pb.go holds the message types for proto/fusion.proto. It is
+// hand-written to match that schema rather than produced by protoc, since
+// this repo has no codegen step; if the .proto changes, update this file
+// by hand alongside it.
+
+package fusionpb
+
+import (
+	"fmt"
+)
+
+// BinaryFusionResult mirrors fusion.BinaryFusionResult for wire transfer.
+type BinaryFusionResult struct {
+	K                 int64  `protobuf:"varint,1,opt,name=k,proto3" json:"k,omitempty"`
+	SeedValue         []byte `protobuf:"bytes,2,opt,name=seed_value,json=seedValue,proto3" json:"seed_value,omitempty"`
+	BinarySeed        string `protobuf:"bytes,3,opt,name=binary_seed,json=binarySeed,proto3" json:"binary_seed,omitempty"`
+	TapState          []byte `protobuf:"bytes,4,opt,name=tap_state,json=tapState,proto3" json:"tap_state,omitempty"`
+	ZPEOverflow       []byte `protobuf:"bytes,5,opt,name=zpe_overflow,json=zpeOverflow,proto3" json:"zpe_overflow,omitempty"`
+	ZPEOverflowBinary string `protobuf:"bytes,6,opt,name=zpe_overflow_binary,json=zpeOverflowBinary,proto3" json:"zpe_overflow_binary,omitempty"`
+}
+
+func (m *BinaryFusionResult) Reset()         { *m = BinaryFusionResult{} }
+func (m *BinaryFusionResult) String() string { return fmt.Sprintf("%+v", *m) }
+func (*BinaryFusionResult) ProtoMessage()    {}
+
+// GenerateRequest asks for the tap at k, or a half-open range [k_start, k_end).
+type GenerateRequest struct {
+	K      int64 `protobuf:"varint,1,opt,name=k,proto3" json:"k,omitempty"`
+	KStart int64 `protobuf:"varint,2,opt,name=k_start,json=kStart,proto3" json:"k_start,omitempty"`
+	KEnd   int64 `protobuf:"varint,3,opt,name=k_end,json=kEnd,proto3" json:"k_end,omitempty"`
+}
+
+func (m *GenerateRequest) Reset()         { *m = GenerateRequest{} }
+func (m *GenerateRequest) String() string { return fmt.Sprintf("%+v", *m) }
+func (*GenerateRequest) ProtoMessage()    {}
+
+// VerifyResponse reports whether a BinaryFusionResult recomputes cleanly from K.
+type VerifyResponse struct {
+	Valid   bool   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *VerifyResponse) Reset()         { *m = VerifyResponse{} }
+func (m *VerifyResponse) String() string { return fmt.Sprintf("%+v", *m) }
+func (*VerifyResponse) ProtoMessage()    {}
+
+func (m *BinaryFusionResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	n += sovFusion(uint64(m.K)) + 1
+	n += sizeBytesField(m.SeedValue, 2)
+	n += sizeStringField(m.BinarySeed, 3)
+	n += sizeBytesField(m.TapState, 4)
+	n += sizeBytesField(m.ZPEOverflow, 5)
+	n += sizeStringField(m.ZPEOverflowBinary, 6)
+	return n
+}
+
+func (m *BinaryFusionResult) Marshal() ([]byte, error) {
+	dAtA := make([]byte, m.Size())
+	n, err := m.MarshalTo(dAtA)
+	return dAtA[:n], err
+}
+
+func (m *BinaryFusionResult) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	i = putVarintField(dAtA, i, 1, uint64(m.K))
+	i = putBytesField(dAtA, i, 2, m.SeedValue)
+	i = putStringField(dAtA, i, 3, m.BinarySeed)
+	i = putBytesField(dAtA, i, 4, m.TapState)
+	i = putBytesField(dAtA, i, 5, m.ZPEOverflow)
+	i = putStringField(dAtA, i, 6, m.ZPEOverflowBinary)
+	return i, nil
+}
+
+func (m *BinaryFusionResult) Unmarshal(dAtA []byte) error {
+	return unmarshalFields(dAtA, func(fieldNum int, wireType int, data []byte) error {
+		switch fieldNum {
+		case 1:
+			m.K = int64(decodeVarint(data))
+		case 2:
+			m.SeedValue = append([]byte(nil), data...)
+		case 3:
+			m.BinarySeed = string(data)
+		case 4:
+			m.TapState = append([]byte(nil), data...)
+		case 5:
+			m.ZPEOverflow = append([]byte(nil), data...)
+		case 6:
+			m.ZPEOverflowBinary = string(data)
+		}
+		return nil
+	})
+}
+
+func (m *GenerateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	n += sovFusion(uint64(m.K)) + 1
+	n += sovFusion(uint64(m.KStart)) + 1
+	n += sovFusion(uint64(m.KEnd)) + 1
+	return n
+}
+
+func (m *GenerateRequest) Marshal() ([]byte, error) {
+	dAtA := make([]byte, m.Size())
+	n, err := m.MarshalTo(dAtA)
+	return dAtA[:n], err
+}
+
+func (m *GenerateRequest) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	i = putVarintField(dAtA, i, 1, uint64(m.K))
+	i = putVarintField(dAtA, i, 2, uint64(m.KStart))
+	i = putVarintField(dAtA, i, 3, uint64(m.KEnd))
+	return i, nil
+}
+
+func (m *GenerateRequest) Unmarshal(dAtA []byte) error {
+	return unmarshalFields(dAtA, func(fieldNum int, wireType int, data []byte) error {
+		switch fieldNum {
+		case 1:
+			m.K = int64(decodeVarint(data))
+		case 2:
+			m.KStart = int64(decodeVarint(data))
+		case 3:
+			m.KEnd = int64(decodeVarint(data))
+		}
+		return nil
+	})
+}
+
+func (m *VerifyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	n += 2 // bool field 1
+	n += sizeStringField(m.Message, 2)
+	return n
+}
+
+func (m *VerifyResponse) Marshal() ([]byte, error) {
+	dAtA := make([]byte, m.Size())
+	n, err := m.MarshalTo(dAtA)
+	return dAtA[:n], err
+}
+
+func (m *VerifyResponse) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	var v uint64
+	if m.Valid {
+		v = 1
+	}
+	i = putVarintField(dAtA, i, 1, v)
+	i = putStringField(dAtA, i, 2, m.Message)
+	return i, nil
+}
+
+func (m *VerifyResponse) Unmarshal(dAtA []byte) error {
+	return unmarshalFields(dAtA, func(fieldNum int, wireType int, data []byte) error {
+		switch fieldNum {
+		case 1:
+			m.Valid = decodeVarint(data) != 0
+		case 2:
+			m.Message = string(data)
+		}
+		return nil
+	})
+}
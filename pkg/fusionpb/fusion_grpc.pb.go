@@ -0,0 +1,181 @@
+// fusion_grpc.pb.go holds the gRPC client/server stubs for proto/fusion.proto.
+// It is hand-written to match that schema rather than produced by
+// protoc-gen-go-grpc, since this repo has no codegen step; if the .proto
+// changes, update this file by hand alongside it.
+
+package fusionpb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	FusionTap_Generate_FullMethodName       = "/fusion.FusionTap/Generate"
+	FusionTap_GenerateStream_FullMethodName = "/fusion.FusionTap/GenerateStream"
+	FusionTap_Verify_FullMethodName         = "/fusion.FusionTap/Verify"
+)
+
+// FusionTapClient is the client API for the FusionTap service.
+type FusionTapClient interface {
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*BinaryFusionResult, error)
+	GenerateStream(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (FusionTap_GenerateStreamClient, error)
+	Verify(ctx context.Context, in *BinaryFusionResult, opts ...grpc.CallOption) (*VerifyResponse, error)
+}
+
+type fusionTapClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewFusionTapClient returns a client backed by cc.
+func NewFusionTapClient(cc grpc.ClientConnInterface) FusionTapClient {
+	return &fusionTapClient{cc}
+}
+
+func (c *fusionTapClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*BinaryFusionResult, error) {
+	out := new(BinaryFusionResult)
+	if err := c.cc.Invoke(ctx, FusionTap_Generate_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fusionTapClient) GenerateStream(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (FusionTap_GenerateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FusionTap_ServiceDesc.Streams[0], FusionTap_GenerateStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fusionTapGenerateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// FusionTap_GenerateStreamClient is the stream returned by GenerateStream.
+type FusionTap_GenerateStreamClient interface {
+	Recv() (*BinaryFusionResult, error)
+	grpc.ClientStream
+}
+
+type fusionTapGenerateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *fusionTapGenerateStreamClient) Recv() (*BinaryFusionResult, error) {
+	m := new(BinaryFusionResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fusionTapClient) Verify(ctx context.Context, in *BinaryFusionResult, opts ...grpc.CallOption) (*VerifyResponse, error) {
+	out := new(VerifyResponse)
+	if err := c.cc.Invoke(ctx, FusionTap_Verify_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FusionTapServer is the server API for the FusionTap service.
+type FusionTapServer interface {
+	Generate(context.Context, *GenerateRequest) (*BinaryFusionResult, error)
+	GenerateStream(*GenerateRequest, FusionTap_GenerateStreamServer) error
+	Verify(context.Context, *BinaryFusionResult) (*VerifyResponse, error)
+}
+
+// UnimplementedFusionTapServer can be embedded to satisfy FusionTapServer
+// for servers that only implement a subset of the RPCs.
+type UnimplementedFusionTapServer struct{}
+
+func (UnimplementedFusionTapServer) Generate(context.Context, *GenerateRequest) (*BinaryFusionResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedFusionTapServer) GenerateStream(*GenerateRequest, FusionTap_GenerateStreamServer) error {
+	return status.Error(codes.Unimplemented, "method GenerateStream not implemented")
+}
+func (UnimplementedFusionTapServer) Verify(context.Context, *BinaryFusionResult) (*VerifyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Verify not implemented")
+}
+
+// FusionTap_GenerateStreamServer is the stream passed to a GenerateStream implementation.
+type FusionTap_GenerateStreamServer interface {
+	Send(*BinaryFusionResult) error
+	grpc.ServerStream
+}
+
+type fusionTapGenerateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *fusionTapGenerateStreamServer) Send(m *BinaryFusionResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterFusionTapServer registers srv with s.
+func RegisterFusionTapServer(s grpc.ServiceRegistrar, srv FusionTapServer) {
+	s.RegisterService(&FusionTap_ServiceDesc, srv)
+}
+
+func _FusionTap_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FusionTapServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: FusionTap_Generate_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FusionTapServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FusionTap_GenerateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FusionTapServer).GenerateStream(m, &fusionTapGenerateStreamServer{stream})
+}
+
+func _FusionTap_Verify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryFusionResult)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FusionTapServer).Verify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: FusionTap_Verify_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FusionTapServer).Verify(ctx, req.(*BinaryFusionResult))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FusionTap_ServiceDesc is the grpc.ServiceDesc for the FusionTap service.
+var FusionTap_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fusion.FusionTap",
+	HandlerType: (*FusionTapServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Generate", Handler: _FusionTap_Generate_Handler},
+		{MethodName: "Verify", Handler: _FusionTap_Verify_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateStream",
+			Handler:       _FusionTap_GenerateStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/fusion.proto",
+}
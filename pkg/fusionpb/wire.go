@@ -0,0 +1,145 @@
+// wire.go holds the proto3 varint/length-delimited encoding helpers shared
+// by every message in this package. These are hand-written to match the
+// wire format in proto/fusion.proto, not generated by protoc.
+
+package fusionpb
+
+import "fmt"
+
+func sovFusion(v uint64) (n int) {
+	for {
+		n++
+		v >>= 7
+		if v == 0 {
+			break
+		}
+	}
+	return n
+}
+
+// tagSize returns the size in bytes of the varint-encoded tag for a
+// length-delimited field (wire type 2) with the given field number.
+func tagSize(fieldNum int) int {
+	return sovFusion(uint64(fieldNum)<<3 | 2)
+}
+
+func sizeBytesField(b []byte, fieldNum int) int {
+	if len(b) == 0 {
+		return 0
+	}
+	return tagSize(fieldNum) + sovFusion(uint64(len(b))) + len(b)
+}
+
+func sizeStringField(s string, fieldNum int) int {
+	if len(s) == 0 {
+		return 0
+	}
+	return tagSize(fieldNum) + sovFusion(uint64(len(s))) + len(s)
+}
+
+func encodeVarintFusion(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+
+func putVarintField(dAtA []byte, i, fieldNum int, v uint64) int {
+	i = encodeVarintFusion(dAtA, i, uint64(fieldNum)<<3)
+	return encodeVarintFusion(dAtA, i, v)
+}
+
+func putBytesField(dAtA []byte, i, fieldNum int, b []byte) int {
+	if len(b) == 0 {
+		return i
+	}
+	i = encodeVarintFusion(dAtA, i, uint64(fieldNum)<<3|2)
+	i = encodeVarintFusion(dAtA, i, uint64(len(b)))
+	return i + copy(dAtA[i:], b)
+}
+
+func putStringField(dAtA []byte, i, fieldNum int, s string) int {
+	return putBytesField(dAtA, i, fieldNum, []byte(s))
+}
+
+func decodeVarint(dAtA []byte) uint64 {
+	var v uint64
+	var shift uint
+	for _, b := range dAtA {
+		v |= uint64(b&0x7f) << shift
+		shift += 7
+	}
+	return v
+}
+
+// unmarshalFields walks a proto3 wire-format message, calling set for every
+// length-delimited or varint field it finds with the already-decoded field
+// payload (raw bytes for wire type 2, the value re-encoded as a varint for
+// decodeVarint otherwise).
+func unmarshalFields(dAtA []byte, set func(fieldNum, wireType int, data []byte) error) error {
+	i := 0
+	for i < len(dAtA) {
+		tag, n := readVarint(dAtA[i:])
+		if n == 0 {
+			return fmt.Errorf("fusionpb: invalid tag")
+		}
+		i += n
+		fieldNum := int(tag >> 3)
+		wireType := int(tag & 0x7)
+
+		switch wireType {
+		case 0: // varint
+			v, n := readVarint(dAtA[i:])
+			if n == 0 {
+				return fmt.Errorf("fusionpb: invalid varint for field %d", fieldNum)
+			}
+			if err := set(fieldNum, wireType, encodeVarintBytes(v)); err != nil {
+				return err
+			}
+			i += n
+		case 2: // length-delimited
+			l, n := readVarint(dAtA[i:])
+			if n == 0 {
+				return fmt.Errorf("fusionpb: invalid length for field %d", fieldNum)
+			}
+			i += n
+			if i+int(l) > len(dAtA) {
+				return fmt.Errorf("fusionpb: truncated field %d", fieldNum)
+			}
+			if err := set(fieldNum, wireType, dAtA[i:i+int(l)]); err != nil {
+				return err
+			}
+			i += int(l)
+		default:
+			return fmt.Errorf("fusionpb: unsupported wire type %d for field %d", wireType, fieldNum)
+		}
+	}
+	return nil
+}
+
+// encodeVarintBytes re-encodes a decoded varint so callers can use the same
+// decodeVarint helper regardless of wire type.
+func encodeVarintBytes(v uint64) []byte {
+	buf := make([]byte, 10)
+	n := encodeVarintFusion(buf, 0, v)
+	return buf[:n]
+}
+
+func readVarint(dAtA []byte) (uint64, int) {
+	var v uint64
+	var shift uint
+	for i, b := range dAtA {
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, i + 1
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, 0
+		}
+	}
+	return 0, 0
+}
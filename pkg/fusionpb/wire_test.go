@@ -0,0 +1,108 @@
+package fusionpb
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+)
+
+func TestSizeBytesFieldAccountsForTagSize(t *testing.T) {
+	b := []byte{0x01, 0x02, 0x03}
+
+	// Field numbers 1-15 fit their tag in a single byte.
+	if got, want := sizeBytesField(b, 1), 1+1+len(b); got != want {
+		t.Fatalf("sizeBytesField(b, 1) = %d, want %d", got, want)
+	}
+
+	// Field number 16 is the first to need a 2-byte varint tag
+	// (16<<3|2 = 130, which does not fit in 7 bits).
+	if got, want := sizeBytesField(b, 16), 2+1+len(b); got != want {
+		t.Fatalf("sizeBytesField(b, 16) = %d, want %d", got, want)
+	}
+}
+
+func TestSizeStringFieldAccountsForTagSize(t *testing.T) {
+	s := "hello"
+
+	if got, want := sizeStringField(s, 1), 1+1+len(s); got != want {
+		t.Fatalf("sizeStringField(s, 1) = %d, want %d", got, want)
+	}
+	if got, want := sizeStringField(s, 16), 2+1+len(s); got != want {
+		t.Fatalf("sizeStringField(s, 16) = %d, want %d", got, want)
+	}
+}
+
+func TestBinaryFusionResultMarshalUnmarshalRoundTrip(t *testing.T) {
+	want := &BinaryFusionResult{
+		K:                 11,
+		SeedValue:         []byte{1, 2, 3},
+		BinarySeed:        "1011",
+		TapState:          []byte{4, 5, 6},
+		ZPEOverflow:       []byte{7, 8},
+		ZPEOverflowBinary: "111",
+	}
+
+	data, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if len(data) != want.Size() {
+		t.Fatalf("Marshal produced %d bytes, Size() said %d", len(data), want.Size())
+	}
+
+	got := &BinaryFusionResult{}
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if got.K != want.K || got.BinarySeed != want.BinarySeed || got.ZPEOverflowBinary != want.ZPEOverflowBinary ||
+		string(got.SeedValue) != string(want.SeedValue) || string(got.TapState) != string(want.TapState) ||
+		string(got.ZPEOverflow) != string(want.ZPEOverflow) {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestGenerateRequestMarshalUnmarshalRoundTrip(t *testing.T) {
+	want := &GenerateRequest{K: 5, KStart: 1, KEnd: 20}
+
+	data, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got := &GenerateRequest{}
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if *got != *want {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestVerifyResponseMarshalUnmarshalRoundTrip(t *testing.T) {
+	want := &VerifyResponse{Valid: true, Message: "ok"}
+
+	data, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got := &VerifyResponse{}
+	if err := got.Unmarshal(data); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if *got != *want {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", got, want)
+	}
+}
+
+func TestCodecDoesNotOverrideGlobalProtoCodec(t *testing.T) {
+	if got := (gogoCodec{}).Name(); got == "proto" {
+		t.Fatalf("codec must not register under grpc's default %q content-subtype", got)
+	}
+	if got := encoding.GetCodec("proto"); got != nil {
+		if _, ok := got.(gogoCodec); ok {
+			t.Fatal("gogoCodec must not be registered as the global \"proto\" codec")
+		}
+	}
+}
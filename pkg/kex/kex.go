@@ -0,0 +1,335 @@
+// Package kex implements a Station-to-Station authenticated Diffie-Hellman
+// exchange whose group parameters are derived from a fusion.BinaryFusionResult.
+// The tap's SeedValue and TapState act as domain separators so that two
+// peers who agree on a K also agree on a group, without needing to ship a
+// standard prime alongside the handshake.
+package kex
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrNoSafePrime is returned when DeriveGroup exhausts its search budget
+// without finding a safe prime.
+var ErrNoSafePrime = errors.New("kex: no safe prime found within search budget")
+
+const maxPrimeAttempts = 1 << 16
+
+// DeriveGroup deterministically derives a safe prime p (p = 2q+1, q prime)
+// and a generator g of the order-q subgroup from res. Peers that agree on
+// the same BinaryFusionResult agree on the same group.
+func DeriveGroup(res *fusion.BinaryFusionResult) (p, g *big.Int, err error) {
+	candidate := new(big.Int).Or(res.TapState, big.NewInt(1))
+	two := big.NewInt(2)
+
+	for i := 0; i < maxPrimeAttempts; i++ {
+		q := new(big.Int).Rsh(candidate, 1) // (candidate-1)/2 since candidate is odd
+		if candidate.ProbablyPrime(20) && q.ProbablyPrime(20) {
+			p = candidate
+			for h := int64(2); h < 1<<20; h++ {
+				cand := new(big.Int).Exp(big.NewInt(h), two, p)
+				if cand.Cmp(big.NewInt(1)) != 0 {
+					g = cand
+					break
+				}
+			}
+			if g == nil {
+				return nil, nil, fmt.Errorf("kex: no generator found for prime %s", p.String())
+			}
+			return p, g, nil
+		}
+		candidate = new(big.Int).Add(candidate, two)
+	}
+	return nil, nil, ErrNoSafePrime
+}
+
+// Session is one side of an STS handshake seeded from a BinaryFusionResult.
+type Session struct {
+	P, G *big.Int
+	Priv crypto.Signer
+	Hash crypto.Hash
+
+	// PeerPublicKey, if set, pins the expected peer identity. If nil, the
+	// peer's public key is accepted as received (trust-on-first-use).
+	PeerPublicKey crypto.PublicKey
+
+	domainSep []byte
+}
+
+// NewSession derives the group from res and returns a Session ready to
+// Initiate or Accept a handshake. Only Accept signs with priv: the
+// initiator verifies the responder's signature but sends none of its own,
+// so Accept does not authenticate the initiator.
+func NewSession(res *fusion.BinaryFusionResult, priv crypto.Signer, hash crypto.Hash) (*Session, error) {
+	p, g, err := DeriveGroup(res)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		P:         p,
+		G:         g,
+		Priv:      priv,
+		Hash:      hash,
+		domainSep: res.ZPEOverflow.Bytes(),
+	}, nil
+}
+
+// Initiate runs the initiator (A) side of the handshake over rw and
+// returns the derived session key.
+func (s *Session) Initiate(rw io.ReadWriter) ([]byte, error) {
+	x, err := s.randExponent()
+	if err != nil {
+		return nil, err
+	}
+	gx := new(big.Int).Exp(s.G, x, s.P)
+	if err := writeFrame(rw, gx.Bytes()); err != nil {
+		return nil, err
+	}
+
+	gyBytes, err := readFrame(rw)
+	if err != nil {
+		return nil, err
+	}
+	pubDER, err := readFrame(rw)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := readFrame(rw)
+	if err != nil {
+		return nil, err
+	}
+
+	gy := new(big.Int).SetBytes(gyBytes)
+	if err := s.validatePublicValue(gy); err != nil {
+		return nil, fmt.Errorf("kex: responder's public value: %w", err)
+	}
+	shared := new(big.Int).Exp(gy, x, s.P)
+	key := s.deriveKey(shared)
+
+	sig, err := decrypt(key, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("kex: decrypt responder signature: %w", err)
+	}
+
+	peerKey, err := s.resolvePeerKey(pubDER)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verifyTranscript(peerKey, gy, gx, sig); err != nil {
+		return nil, fmt.Errorf("kex: responder signature invalid: %w", err)
+	}
+
+	return key, nil
+}
+
+// Accept runs the responder (B) side of the handshake over rw and returns
+// the derived session key.
+func (s *Session) Accept(rw io.ReadWriter) ([]byte, error) {
+	gxBytes, err := readFrame(rw)
+	if err != nil {
+		return nil, err
+	}
+	gx := new(big.Int).SetBytes(gxBytes)
+	if err := s.validatePublicValue(gx); err != nil {
+		return nil, fmt.Errorf("kex: initiator's public value: %w", err)
+	}
+
+	y, err := s.randExponent()
+	if err != nil {
+		return nil, err
+	}
+	gy := new(big.Int).Exp(s.G, y, s.P)
+	shared := new(big.Int).Exp(gx, y, s.P)
+	key := s.deriveKey(shared)
+
+	sig, err := s.signTranscript(gy, gx)
+	if err != nil {
+		return nil, fmt.Errorf("kex: sign transcript: %w", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(s.Priv.Public())
+	if err != nil {
+		return nil, fmt.Errorf("kex: marshal public key: %w", err)
+	}
+	sealed, err := encrypt(key, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeFrame(rw, gy.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writeFrame(rw, pubDER); err != nil {
+		return nil, err
+	}
+	if err := writeFrame(rw, sealed); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *Session) resolvePeerKey(pubDER []byte) (crypto.PublicKey, error) {
+	if s.PeerPublicKey != nil {
+		return s.PeerPublicKey, nil
+	}
+	return x509.ParsePKIXPublicKey(pubDER)
+}
+
+// validatePublicValue rejects any received g^x/g^y outside (1, p-1) or
+// outside the order-q subgroup, so a peer can't force the shared secret to
+// a fixed, attacker-known value with a low-order element such as 1.
+func (s *Session) validatePublicValue(v *big.Int) error {
+	one := big.NewInt(1)
+	pMinus1 := new(big.Int).Sub(s.P, one)
+	if v.Cmp(one) <= 0 || v.Cmp(pMinus1) >= 0 {
+		return errors.New("value out of range (1, p-1)")
+	}
+	q := new(big.Int).Rsh(s.P, 1) // (p-1)/2, since p is odd
+	if new(big.Int).Exp(v, q, s.P).Cmp(one) != 0 {
+		return errors.New("value not in the order-q subgroup")
+	}
+	return nil
+}
+
+// fixedWidth encodes v as exactly ceil(P.BitLen()/8) bytes so that
+// transcriptMessage can't be re-split ambiguously (big.Int.Bytes() is
+// minimal-length and a leading zero byte would shift the boundary).
+func (s *Session) fixedWidth(v *big.Int) []byte {
+	buf := make([]byte, (s.P.BitLen()+7)/8)
+	v.FillBytes(buf)
+	return buf
+}
+
+func (s *Session) transcriptMessage(gy, gx *big.Int) []byte {
+	msg := make([]byte, 0, 2*len(s.fixedWidth(gy)))
+	msg = append(msg, s.fixedWidth(gy)...)
+	msg = append(msg, s.fixedWidth(gx)...)
+	return msg
+}
+
+// signTranscript signs Sign_B(g^y, g^x). Ed25519 signs the transcript
+// message directly (it does its own hashing); RSA signs the transcript's
+// s.Hash digest, since crypto/rsa expects a pre-hashed message.
+func (s *Session) signTranscript(gy, gx *big.Int) ([]byte, error) {
+	msg := s.transcriptMessage(gy, gx)
+	if _, ok := s.Priv.Public().(ed25519.PublicKey); ok {
+		return s.Priv.Sign(rand.Reader, msg, crypto.Hash(0))
+	}
+	h := s.Hash.New()
+	h.Write(msg)
+	return s.Priv.Sign(rand.Reader, h.Sum(nil), s.Hash)
+}
+
+func (s *Session) verifyTranscript(pub crypto.PublicKey, gy, gx *big.Int, sig []byte) error {
+	msg := s.transcriptMessage(gy, gx)
+	switch k := pub.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, msg, sig) {
+			return errors.New("signature does not verify")
+		}
+		return nil
+	case *rsa.PublicKey:
+		h := s.Hash.New()
+		h.Write(msg)
+		return rsa.VerifyPKCS1v15(k, s.Hash, h.Sum(nil), sig)
+	default:
+		return fmt.Errorf("kex: unsupported public key type %T", pub)
+	}
+}
+
+func (s *Session) deriveKey(shared *big.Int) []byte {
+	r := hkdf.New(s.Hash.New, shared.Bytes(), s.domainSep, []byte("beanapologist/seed kex"))
+	key := make([]byte, 32)
+	io.ReadFull(r, key)
+	return key
+}
+
+// randExponent picks a random exponent in [2, p-2] sized to p.BitLen().
+func (s *Session) randExponent() (*big.Int, error) {
+	upper := new(big.Int).Sub(s.P, big.NewInt(3))
+	x, err := rand.Int(rand.Reader, upper)
+	if err != nil {
+		return nil, fmt.Errorf("kex: generate exponent: %w", err)
+	}
+	return x.Add(x, big.NewInt(2)), nil
+}
+
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, sealed []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("kex: ciphertext too short")
+	}
+	nonce, ct := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ct, nil)
+}
+
+func writeFrame(w io.Writer, data []byte) error {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// maxFrameSize bounds a single readFrame allocation. Frames only ever carry
+// DH public values, a DER-encoded signer public key or a sealed signature
+// blob — none of which come anywhere close to this — so it exists purely
+// to stop an unauthenticated peer from forcing an arbitrarily large
+// allocation via the length prefix before any signature has been checked.
+const maxFrameSize = 64 * 1024
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint32(length[:])
+	if n > maxFrameSize {
+		return nil, fmt.Errorf("kex: frame of %d bytes exceeds the %d byte limit", n, maxFrameSize)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,99 @@
+package kex
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+)
+
+func newTestSession(t *testing.T) *Session {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	s, err := NewSession(fusion.BinaryFusionTap(11), priv, crypto.SHA256)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	return s
+}
+
+func TestHandshakeRoundTrip(t *testing.T) {
+	a := newTestSession(t)
+	b := newTestSession(t) // derives the same group from the same BinaryFusionResult
+
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	results := make(chan []byte, 1)
+	errs := make(chan error, 2)
+	go func() {
+		key, err := a.Initiate(client)
+		if err != nil {
+			errs <- err
+			return
+		}
+		results <- key
+	}()
+	go func() {
+		if _, err := b.Accept(server); err != nil {
+			errs <- err
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		t.Fatalf("handshake failed: %v", err)
+	case key := <-results:
+		if len(key) == 0 {
+			t.Fatal("empty session key")
+		}
+	}
+}
+
+func TestValidatePublicValueRejectsLowOrderElement(t *testing.T) {
+	s := newTestSession(t)
+	if err := s.validatePublicValue(big.NewInt(1)); err == nil {
+		t.Fatal("expected g^x=1 to be rejected")
+	}
+	pMinus1 := new(big.Int).Sub(s.P, big.NewInt(1))
+	if err := s.validatePublicValue(pMinus1); err == nil {
+		t.Fatal("expected g^x=p-1 (order 2) to be rejected")
+	}
+}
+
+func TestReadFrameRejectsOversizedLengthPrefix(t *testing.T) {
+	var buf bytes.Buffer
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], maxFrameSize+1)
+	buf.Write(length[:])
+
+	if _, err := readFrame(&buf); err == nil {
+		t.Fatal("expected an error for a length prefix past maxFrameSize")
+	}
+}
+
+func TestReadFrameAcceptsMaxSizeFrame(t *testing.T) {
+	var buf bytes.Buffer
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], maxFrameSize)
+	buf.Write(length[:])
+	buf.Write(make([]byte, maxFrameSize))
+
+	data, err := readFrame(&buf)
+	if err != nil {
+		t.Fatalf("readFrame: %v", err)
+	}
+	if len(data) != maxFrameSize {
+		t.Fatalf("expected %d bytes, got %d", maxFrameSize, len(data))
+	}
+}
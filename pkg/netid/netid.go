@@ -0,0 +1,73 @@
+// Package netid maps a fusion.BinaryFusionResult to network primitives —
+// IPv6 addresses, RFC 4193 unique local addresses and locally administered
+// MACs — so overlay networks and testbeds can have their address plan
+// fully determined by the fusion tap parameters.
+package netid
+
+import (
+	"encoding/binary"
+	"net"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+)
+
+// fold reduces b to exactly n bytes: left-padded with zeros when it is
+// shorter, XOR-folded in n-byte blocks when it is longer.
+func fold(b []byte, n int) []byte {
+	out := make([]byte, n)
+	if len(b) <= n {
+		copy(out[n-len(b):], b)
+		return out
+	}
+	for i, by := range b {
+		out[i%n] ^= by
+	}
+	return out
+}
+
+// TapStateToIPv6 maps res.TapState to a 16-byte net.IP, left-padding short
+// states and XOR-folding states wider than 128 bits.
+func TapStateToIPv6(res *fusion.BinaryFusionResult) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, fold(res.TapState.Bytes(), net.IPv6len))
+	return ip
+}
+
+// IPv6ToTapState recovers the bytes TapStateToIPv6 produced. It is an exact
+// inverse only when the original TapState fit in 128 bits without folding.
+func IPv6ToTapState(ip net.IP) []byte {
+	ip16 := ip.To16()
+	out := make([]byte, len(ip16))
+	copy(out, ip16)
+	return out
+}
+
+// TapStateToULA derives an RFC 4193 unique local address /64 prefix: the
+// top byte forced to 0xfd, a 40-bit global ID folded from ZPEOverflow, and
+// the caller-supplied subnetID packed into the next 16 bits.
+func TapStateToULA(res *fusion.BinaryFusionResult, subnetID uint16) *net.IPNet {
+	ip := make(net.IP, net.IPv6len)
+	ip[0] = 0xfd
+	copy(ip[1:6], fold(res.ZPEOverflow.Bytes(), 5))
+	binary.BigEndian.PutUint16(ip[6:8], subnetID)
+
+	mask := net.CIDRMask(64, 128)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// TapStateToMAC derives a locally administered, unicast 48-bit MAC from
+// res.TapState.
+func TapStateToMAC(res *fusion.BinaryFusionResult) net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	copy(mac, fold(res.TapState.Bytes(), 6))
+	mac[0] = (mac[0] &^ 0x01) | 0x02 // unicast, locally administered
+	return mac
+}
+
+// MACToTapState recovers the bytes TapStateToMAC produced, modulo the
+// unicast/locally-administered bits TapStateToMAC forces on byte 0.
+func MACToTapState(mac net.HardwareAddr) []byte {
+	out := make([]byte, len(mac))
+	copy(out, mac)
+	return out
+}
@@ -0,0 +1,89 @@
+package netid
+
+import (
+	"net"
+	"testing"
+
+	"github.com/beanapologist/seed/pkg/fusion"
+)
+
+func TestTapStateToIPv6RoundTrip(t *testing.T) {
+	for k := 1; k <= 20; k++ {
+		res := fusion.BinaryFusionTap(k)
+		ip := TapStateToIPv6(res)
+		if len(ip) != net.IPv6len {
+			t.Fatalf("k=%d: expected a %d-byte IP, got %d", k, net.IPv6len, len(ip))
+		}
+
+		want := fold(res.TapState.Bytes(), net.IPv6len)
+		got := IPv6ToTapState(ip)
+		if string(got) != string(want) {
+			t.Fatalf("k=%d: IPv6ToTapState round trip mismatch", k)
+		}
+	}
+}
+
+func TestTapStateToULA(t *testing.T) {
+	for k := 1; k <= 20; k++ {
+		res := fusion.BinaryFusionTap(k)
+		n := TapStateToULA(res, 0x1234)
+
+		if n.IP[0] != 0xfd {
+			t.Fatalf("k=%d: expected top byte 0xfd, got 0x%02x", k, n.IP[0])
+		}
+		ones, bits := n.Mask.Size()
+		if ones != 64 || bits != 128 {
+			t.Fatalf("k=%d: expected a /64 mask, got /%d (of %d)", k, ones, bits)
+		}
+
+		wantGlobalID := fold(res.ZPEOverflow.Bytes(), 5)
+		if string(n.IP[1:6]) != string(wantGlobalID) {
+			t.Fatalf("k=%d: global ID mismatch", k)
+		}
+
+		// k < 10 takes the zero-overflow branch in BinaryFusionTap, so the
+		// global ID folded from it must be all zero.
+		if k < 10 {
+			for _, b := range n.IP[1:6] {
+				if b != 0 {
+					t.Fatalf("k=%d: expected zero global ID for the zero-overflow branch, got %x", k, n.IP[1:6])
+				}
+			}
+		}
+	}
+}
+
+func TestTapStateToMAC(t *testing.T) {
+	for k := 1; k <= 20; k++ {
+		res := fusion.BinaryFusionTap(k)
+		mac := TapStateToMAC(res)
+
+		if len(mac) != 6 {
+			t.Fatalf("k=%d: expected a 6-byte MAC, got %d", k, len(mac))
+		}
+		if mac[0]&0x01 != 0 {
+			t.Fatalf("k=%d: expected a unicast MAC, got multicast bit set: %v", k, mac)
+		}
+		if mac[0]&0x02 == 0 {
+			t.Fatalf("k=%d: expected the locally-administered bit set: %v", k, mac)
+		}
+
+		got := MACToTapState(mac)
+		if len(got) != 6 {
+			t.Fatalf("k=%d: MACToTapState returned %d bytes, want 6", k, len(got))
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	// Shorter than n: left-padded with zeros.
+	if got := fold([]byte{0x01}, 4); string(got) != string([]byte{0x00, 0x00, 0x00, 0x01}) {
+		t.Fatalf("short input not left-padded: %x", got)
+	}
+	// Longer than n: XOR-folded in n-byte blocks.
+	got := fold([]byte{0x01, 0x02, 0x03, 0x04, 0x05}, 4)
+	want := []byte{0x01 ^ 0x05, 0x02, 0x03, 0x04}
+	if string(got) != string(want) {
+		t.Fatalf("fold(%v, 4) = %x, want %x", []byte{1, 2, 3, 4, 5}, got, want)
+	}
+}